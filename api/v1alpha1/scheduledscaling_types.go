@@ -54,10 +54,36 @@ type ScheduledScalingStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	ScheduledScalingPhase ScheduledScalingPhase `json:"scheduledScalingPhase" protobuf:"bytes,1,name=scheduledScalingPhase"`
+	// LastTransitionTime is the last time the phase transitioned from one state to another.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,2,opt,name=lastTransitionTime"`
+	// LastScheduleTime is the last time a Cron recurrence was found to be active.
+	// Only set when Schedule.Cron is used.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty" protobuf:"bytes,3,opt,name=lastScheduleTime"`
+	// NextScheduleTime is the next time the phase is expected to change: the next Cron
+	// recurrence, the end of the active Cron window, or the next StartAt/FinishAt boundary.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty" protobuf:"bytes,4,opt,name=nextScheduleTime"`
+	// FollowerBaselines holds the replica ratio captured for each of TargetRefs.Followers the
+	// first time the overlay was applied.
+	// +optional
+	FollowerBaselines []FollowerBaseline `json:"followerBaselines,omitempty" protobuf:"bytes,5,rep,name=followerBaselines"`
 }
 
 type ScheduledScalingPhase string
 
+const (
+	// ScheduledScalingPhasePending means the schedule window hasn't started yet.
+	ScheduledScalingPhasePending ScheduledScalingPhase = "Pending"
+	// ScheduledScalingPhaseActive means the schedule window is currently applied to the target Tortoise.
+	ScheduledScalingPhaseActive ScheduledScalingPhase = "Active"
+	// ScheduledScalingPhaseCompleted means the schedule window has finished and the overlay has been reverted.
+	ScheduledScalingPhaseCompleted ScheduledScalingPhase = "Completed"
+	// ScheduledScalingPhaseFailed means the controller couldn't resolve the target Tortoise or its schedule window.
+	ScheduledScalingPhaseFailed ScheduledScalingPhase = "Failed"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
@@ -85,6 +111,22 @@ type TargetRefs struct {
 	ScaleTargetRef CrossVersionObjectReference `json:"scaleTargetRef" protobuf:"bytes,1,name=scaleTargetRef"`
 	//Tortoise to be targeted for scheduled scaling
 	TortoiseName *string `json:"tortoiseName,omitempty" protobuf:"bytes,2,name=tortoiseName"`
+	// Followers are additional scale targets that should be scaled proportionally alongside
+	// ScaleTargetRef, e.g. a cache or worker deployment that fans out from a web tier. Their
+	// capacity is kept at the same ratio to ScaleTargetRef's as it was when the ScheduledScaling
+	// first went active; see Status.FollowerBaselines.
+	// +optional
+	Followers []CrossVersionObjectReference `json:"followers,omitempty" protobuf:"bytes,3,rep,name=followers"`
+}
+
+// FollowerBaseline records the replica ratio of a follower relative to the primary ScaleTargetRef,
+// captured the first time the ScheduledScaling applies its overlay.
+type FollowerBaseline struct {
+	// FollowerRef identifies which follower this baseline belongs to.
+	FollowerRef CrossVersionObjectReference `json:"followerRef" protobuf:"bytes,1,name=followerRef"`
+	// Ratio is the follower's replica count divided by the primary's replica count, observed at
+	// the moment the ScheduledScaling first applied its overlay.
+	Ratio float64 `json:"ratio" protobuf:"fixed64,2,name=ratio"`
 }
 
 // CrossVersionObjectReference contains enough information toet identify the referred resource.
@@ -101,11 +143,28 @@ type CrossVersionObjectReference struct {
 }
 
 type Schedule struct {
-	/// The schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
-	// start of schedule
+	// start of schedule, RFC3339. Mutually exclusive with Cron.
 	StartAt *string `json:"startAt,omitempty" protobuf:"bytes,1,opt,name=startAt"`
-	// end of schedule
+	// end of schedule, RFC3339. Mutually exclusive with Cron.
 	FinishAt *string `json:"finishAt,omitempty" protobuf:"bytes,2,name=finishAt"`
+
+	// Cron is a recurring schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
+	// Mutually exclusive with StartAt/FinishAt, and requires Duration to also be set.
+	// +optional
+	Cron *string `json:"cron,omitempty" protobuf:"bytes,3,opt,name=cron"`
+	// Duration is how long the ScheduledScaling stays active after each Cron recurrence fires.
+	// Required when Cron is set.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty" protobuf:"bytes,4,opt,name=duration"`
+	// TimeZone is the IANA time zone name (e.g. "Asia/Tokyo") Cron is evaluated in.
+	// Defaults to UTC. Only used when Cron is set.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty" protobuf:"bytes,5,opt,name=timeZone"`
+	// StartingDeadlineSeconds is the deadline, in seconds, for starting a Cron recurrence that
+	// was missed (e.g. because the controller was down). Recurrences older than this are
+	// skipped, analogous to CronJob.Spec.StartingDeadlineSeconds. Only used when Cron is set.
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty" protobuf:"varint,6,opt,name=startingDeadlineSeconds"`
 }
 
 type Strategy struct {
@@ -129,4 +188,4 @@ type ContainerResourceRequests struct {
 
 func init() {
 	SchemeBuilder.Register(&ScheduledScaling{}, &ScheduledScalingList{})
-}
\ No newline at end of file
+}
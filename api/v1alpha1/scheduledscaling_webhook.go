@@ -0,0 +1,159 @@
+/*
+MIT License
+
+Copyright (c) 2023 mercari
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var scheduledscalinglog = ctrl.Log.WithName("scheduledscaling-resource")
+
+// scheduledscalingWebhookClient is used to list sibling ScheduledScalings when validating that no
+// two of them target the same follower. It's set up once from SetupWebhookWithManager.
+var scheduledscalingWebhookClient client.Reader
+
+func (r *ScheduledScaling) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	scheduledscalingWebhookClient = mgr.GetClient()
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-autoscaling-mercari-com-v1alpha1-scheduledscaling,mutating=false,failurePolicy=fail,sideEffects=None,groups=autoscaling.mercari.com,resources=scheduledscalings,verbs=create;update,versions=v1alpha1,name=vscheduledscaling.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ScheduledScaling{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *ScheduledScaling) ValidateCreate() (admission.Warnings, error) {
+	scheduledscalinglog.Info("validate create", "name", r.Name)
+	if err := r.validateSchedule(); err != nil {
+		return nil, err
+	}
+	return nil, r.validateNoOverlappingFollowers(context.Background())
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *ScheduledScaling) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	scheduledscalinglog.Info("validate update", "name", r.Name)
+	if err := r.validateSchedule(); err != nil {
+		return nil, err
+	}
+	return nil, r.validateNoOverlappingFollowers(context.Background())
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *ScheduledScaling) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSchedule rejects specs that set both the one-shot StartAt/FinishAt fields and the
+// recurring Cron/Duration fields, and makes sure Cron always carries its required Duration.
+func (r *ScheduledScaling) validateSchedule() error {
+	s := r.Spec.Schedule
+
+	oneShot := s.StartAt != nil || s.FinishAt != nil
+	recurring := s.Cron != nil || s.Duration != nil
+
+	if oneShot && recurring {
+		return fmt.Errorf("schedule: startAt/finishAt and cron/duration are mutually exclusive")
+	}
+	if s.Cron != nil && s.Duration == nil {
+		return fmt.Errorf("schedule: duration is required when cron is set")
+	}
+	if s.Duration != nil && s.Cron == nil {
+		return fmt.Errorf("schedule: cron is required when duration is set")
+	}
+	if s.TimeZone != nil && s.Cron == nil {
+		return fmt.Errorf("schedule: timeZone can only be set together with cron")
+	}
+	if s.StartingDeadlineSeconds != nil && s.Cron == nil {
+		return fmt.Errorf("schedule: startingDeadlineSeconds can only be set together with cron")
+	}
+	if !oneShot && !recurring {
+		return fmt.Errorf("schedule: either startAt/finishAt or cron/duration must be set")
+	}
+
+	return nil
+}
+
+// validateNoOverlappingFollowers rejects a ScheduledScaling whose followers are also targeted as a
+// follower by another ScheduledScaling in the same namespace; applying two independently-scaled
+// overlays to the same follower would make its capacity depend on reconcile ordering.
+func (r *ScheduledScaling) validateNoOverlappingFollowers(ctx context.Context) error {
+	if len(r.Spec.TargetRefs.Followers) == 0 || scheduledscalingWebhookClient == nil {
+		return nil
+	}
+
+	list := &ScheduledScalingList{}
+	if err := scheduledscalingWebhookClient.List(ctx, list, client.InNamespace(r.Namespace)); err != nil {
+		return fmt.Errorf("list scheduledscalings: %w", err)
+	}
+
+	for _, other := range list.Items {
+		if other.Name == r.Name {
+			continue
+		}
+		for _, mine := range r.Spec.TargetRefs.Followers {
+			for _, theirs := range other.Spec.TargetRefs.Followers {
+				if followerRefEqual(mine, theirs) {
+					return fmt.Errorf("follower %s is already targeted by ScheduledScaling %q", followerRefString(mine), other.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func followerRefEqual(a, b CrossVersionObjectReference) bool {
+	return ptrStringEqual(a.Kind, b.Kind) && ptrStringEqual(a.Name, b.Name) && ptrStringEqual(a.APIVersion, b.APIVersion)
+}
+
+func ptrStringEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func followerRefString(ref CrossVersionObjectReference) string {
+	kind, name := "", ""
+	if ref.Kind != nil {
+		kind = *ref.Kind
+	}
+	if ref.Name != nil {
+		name = *ref.Name
+	}
+	return fmt.Sprintf("%s/%s", kind, name)
+}
@@ -0,0 +1,49 @@
+/*
+MIT License
+
+Copyright (c) 2023 mercari
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+
+*/
+
+package v1beta3
+
+// VPAConfig configures how Tortoise drives the tortoise-updater VPA it creates for a Tortoise.
+type VPAConfig struct {
+	// UpdateMode controls whether the tortoise-updater VPA is allowed to evict pods to apply its
+	// recommendation. Defaults to Recommend if unset.
+	// +optional
+	UpdateMode VPAUpdateMode `json:"updateMode,omitempty" protobuf:"bytes,1,opt,name=updateMode"`
+}
+
+// VPAUpdateMode is the strategy Tortoise uses to run the tortoise-updater VPA.
+type VPAUpdateMode string
+
+const (
+	// VPAUpdateModeRecommend runs the updater VPA with UpdateModeOff, so it publishes
+	// recommendations without letting the VPA evict pods to apply them.
+	VPAUpdateModeRecommend VPAUpdateMode = "Recommend"
+	// VPAUpdateModeAuto runs the updater VPA with UpdateModeAuto, letting the upstream VPA
+	// admission controller evict pods to apply its recommendation directly.
+	VPAUpdateModeAuto VPAUpdateMode = "Auto"
+	// VPAUpdateModeDisabled stops Tortoise from managing a tortoise-updater VPA for this Tortoise
+	// at all; any updater VPA it previously created is deleted, unless Spec.DeletionPolicy is NoDelete.
+	VPAUpdateModeDisabled VPAUpdateMode = "Disabled"
+)
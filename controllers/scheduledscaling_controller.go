@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mercari/tortoise/api/v1alpha1"
+	"github.com/mercari/tortoise/pkg/event"
+	"github.com/mercari/tortoise/pkg/scheduledscaling"
+)
+
+// ScheduledScalingReconciler reconciles a ScheduledScaling object.
+type ScheduledScalingReconciler struct {
+	Scheme   *runtime.Scheme
+	Client   client.Client
+	Recorder record.EventRecorder
+
+	scheduledScalingService *scheduledscaling.Service
+}
+
+func NewScheduledScalingController(scheduledScalingService *scheduledscaling.Service, scheme *runtime.Scheme, c client.Client, recorder record.EventRecorder) *ScheduledScalingReconciler {
+	return &ScheduledScalingReconciler{
+		Scheme:                  scheme,
+		Client:                  c,
+		Recorder:                recorder,
+		scheduledScalingService: scheduledScalingService,
+	}
+}
+
+//+kubebuilder:rbac:groups=autoscaling.mercari.com,resources=scheduledscalings,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=autoscaling.mercari.com,resources=scheduledscalings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=autoscaling.mercari.com,resources=scheduledscalings/finalizers,verbs=update
+
+func (r *ScheduledScalingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	now := time.Now()
+
+	ss, err := r.scheduledScalingService.GetScheduledScaling(ctx, req.NamespacedName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	tortoise, err := r.scheduledScalingService.GetTortoise(ctx, ss)
+	if err != nil {
+		r.Recorder.Eventf(ss, corev1.EventTypeWarning, event.ScheduledScalingFailed, "Failed to resolve target tortoise: %v", err)
+		failedWindow := scheduledscaling.Window{Phase: v1alpha1.ScheduledScalingPhaseFailed}
+		if statusErr := r.scheduledScalingService.UpdateStatus(ctx, ss, failedWindow, time.Time{}, now); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	window, lastScheduleTime, err := r.scheduledScalingService.ResolveWindow(ss, now)
+	if err != nil {
+		r.Recorder.Eventf(ss, corev1.EventTypeWarning, event.ScheduledScalingFailed, "Failed to resolve schedule window: %v", err)
+		failedWindow := scheduledscaling.Window{Phase: v1alpha1.ScheduledScalingPhaseFailed}
+		if statusErr := r.scheduledScalingService.UpdateStatus(ctx, ss, failedWindow, time.Time{}, now); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	switch window.Phase {
+	case v1alpha1.ScheduledScalingPhaseActive:
+		if err := r.scheduledScalingService.ApplyStaticOverlay(ctx, ss, tortoise); err != nil {
+			return ctrl.Result{}, err
+		}
+	case v1alpha1.ScheduledScalingPhaseCompleted, v1alpha1.ScheduledScalingPhasePending:
+		// A one-shot schedule reports Completed once its window has passed for good; a recurring Cron
+		// schedule instead cycles back to Pending between recurrences, which must revert the overlay
+		// just the same or it leaks past the end of the first active window.
+		if ss.Status.ScheduledScalingPhase == v1alpha1.ScheduledScalingPhaseActive {
+			if err := r.scheduledScalingService.RevertStaticOverlay(ctx, ss, tortoise); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	if err := r.scheduledScalingService.UpdateStatus(ctx, ss, window, lastScheduleTime, now); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if window.NextTransitionAt.IsZero() {
+		// The schedule has already completed; nothing more to requeue for.
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: window.NextTransitionAt.Sub(now)}, nil
+}
+
+func (r *ScheduledScalingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ScheduledScaling{}).
+		Complete(r)
+}
@@ -0,0 +1,26 @@
+// Package event holds the Reason strings Tortoise's controllers and services pass to
+// record.EventRecorder, so the same reason is never spelled two different ways across packages.
+package event
+
+const (
+	// VPACreated is recorded when a monitor or updater VPA is created for a Tortoise.
+	VPACreated = "VPACreated"
+
+	// ScheduledScalingActive is recorded when a ScheduledScaling's static overlay is applied to its
+	// target Tortoise.
+	ScheduledScalingActive = "ScheduledScalingActive"
+	// ScheduledScalingCompleted is recorded when a ScheduledScaling's static overlay is reverted
+	// after its schedule window ends.
+	ScheduledScalingCompleted = "ScheduledScalingCompleted"
+	// ScheduledScalingFailed is recorded when a ScheduledScaling can't resolve its target Tortoise
+	// or its schedule window.
+	ScheduledScalingFailed = "ScheduledScalingFailed"
+	// ScheduledScalingPhaseChanged is recorded whenever Status.ScheduledScalingPhase transitions
+	// from one phase to another.
+	ScheduledScalingPhaseChanged = "ScheduledScalingPhaseChanged"
+
+	// VPADegraded is recorded the first time a monitor VPA's health moves to health.StatusDegraded.
+	VPADegraded = "VPADegraded"
+	// VPAUnhealthy is recorded the first time a monitor VPA's health moves to health.StatusUnhealthy.
+	VPAUnhealthy = "VPAUnhealthy"
+)
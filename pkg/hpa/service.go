@@ -0,0 +1,75 @@
+package hpa
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoscalingv1beta3 "github.com/mercari/tortoise/api/v1beta3"
+)
+
+// Service manages the HPA Tortoise creates and keeps in sync alongside a Tortoise's VPAs.
+type Service struct {
+	c client.Client
+}
+
+func New(c client.Client) *Service {
+	return &Service{c: c}
+}
+
+const tortoiseHPANamePrefix = "tortoise-hpa-"
+
+func TortoiseHPAName(tortoiseName string) string {
+	return tortoiseHPANamePrefix + tortoiseName
+}
+
+// GetTortoiseHPA fetches the HPA Tortoise manages for the given Tortoise.
+func (s *Service) GetTortoiseHPA(ctx context.Context, tortoise *autoscalingv1beta3.Tortoise) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	n := types.NamespacedName{Namespace: tortoise.Namespace, Name: TortoiseHPAName(tortoise.Name)}
+	if err := s.c.Get(ctx, n, hpa); err != nil {
+		return nil, fmt.Errorf("get tortoise hpa %s: %w", n.String(), err)
+	}
+	return hpa, nil
+}
+
+// ApplyMinimumMinReplicas bumps the HPA's MinReplicas up to at least minReplicas, leaving it
+// untouched if it's already at or above that floor. Used by ScheduledScaling to guarantee capacity
+// ahead of a predictable load spike.
+func (s *Service) ApplyMinimumMinReplicas(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler, minReplicas int32) error {
+	if hpa.Spec.MinReplicas != nil && *hpa.Spec.MinReplicas >= minReplicas {
+		return nil
+	}
+
+	updateFn := func() error {
+		hpa.Spec.MinReplicas = &minReplicas
+		return s.c.Update(ctx, hpa)
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, updateFn); err != nil {
+		return fmt.Errorf("update HPA (%s/%s) MinReplicas: %w", hpa.Namespace, hpa.Name, err)
+	}
+	return nil
+}
+
+// RevertMinimumMinReplicas clears the floor ApplyMinimumMinReplicas set on the HPA's MinReplicas,
+// letting Tortoise's regular reconcile recompute it from the autoscaling policy on its next pass.
+func (s *Service) RevertMinimumMinReplicas(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	if hpa.Spec.MinReplicas == nil {
+		return nil
+	}
+
+	updateFn := func() error {
+		hpa.Spec.MinReplicas = nil
+		return s.c.Update(ctx, hpa)
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, updateFn); err != nil {
+		return fmt.Errorf("update HPA (%s/%s) MinReplicas: %w", hpa.Namespace, hpa.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package scaletarget
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	autoscaling "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/scale"
+)
+
+// Target is the resolved scale target of a Tortoise: the GVK to put on a VPA/HPA TargetRef, and
+// the GVR backing its /scale subresource.
+type Target struct {
+	Kind       string
+	APIVersion string
+	GVR        schema.GroupVersionResource
+}
+
+// Service resolves the GroupVersionKind/GroupVersionResource of a Tortoise's scale target from its
+// CrossVersionObjectReference, validating that it implements the /scale subresource. This lets
+// Tortoise manage Deployments, StatefulSets, ArgoRollouts, and any other CRD that exposes /scale.
+type Service struct {
+	disc  discovery.DiscoveryInterface
+	scale scale.ScalesGetter
+
+	mapper meta.RESTMapper
+
+	mu    sync.RWMutex
+	cache map[schema.GroupVersionKind]Target
+}
+
+func New(disc discovery.DiscoveryInterface, scaleClient scale.ScalesGetter) (*Service, error) {
+	groupResources, err := restmapper.GetAPIGroupResources(disc)
+	if err != nil {
+		return nil, fmt.Errorf("discover API group resources: %w", err)
+	}
+
+	return &Service{
+		disc:   disc,
+		scale:  scaleClient,
+		mapper: restmapper.NewDiscoveryRESTMapper(groupResources),
+		cache:  map[schema.GroupVersionKind]Target{},
+	}, nil
+}
+
+// Resolve maps a CrossVersionObjectReference to its GVK/GVR, validating along the way that the
+// target implements the scale subresource. The result is cached by GVK since the mapping is
+// static for the lifetime of the process (barring a CRD being redefined, which requires a restart
+// to pick up regardless, same as any other RESTMapper consumer).
+func (s *Service) Resolve(_ context.Context, ref autoscaling.CrossVersionObjectReference) (Target, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return Target{}, fmt.Errorf("parse apiVersion %q: %w", ref.APIVersion, err)
+	}
+	gvk := gv.WithKind(ref.Kind)
+
+	s.mu.RLock()
+	target, ok := s.cache[gvk]
+	s.mu.RUnlock()
+	if ok {
+		return target, nil
+	}
+
+	mapping, err := s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return Target{}, fmt.Errorf("resolve GVK %s: %w", gvk, err)
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return Target{}, fmt.Errorf("scale target %s is not namespace-scoped", gvk)
+	}
+
+	if err := s.validateScaleSubresource(gv.String(), mapping.Resource.Resource); err != nil {
+		return Target{}, err
+	}
+
+	target = Target{
+		Kind:       ref.Kind,
+		APIVersion: ref.APIVersion,
+		GVR:        mapping.Resource,
+	}
+
+	s.mu.Lock()
+	s.cache[gvk] = target
+	s.mu.Unlock()
+
+	return target, nil
+}
+
+// GetScale fetches the current Scale subresource of the given target, which among other things
+// carries its current replica count.
+func (s *Service) GetScale(ctx context.Context, namespace string, ref autoscaling.CrossVersionObjectReference) (*autoscaling.Scale, error) {
+	target, err := s.Resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	sc, err := s.scale.Scales(namespace).Get(ctx, target.GVR.GroupResource(), ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get scale for %s %s/%s: %w", ref.Kind, namespace, ref.Name, err)
+	}
+	return sc, nil
+}
+
+// UpdateScale pushes the given Scale back to the target's /scale subresource, e.g. to bump its
+// replica count.
+func (s *Service) UpdateScale(ctx context.Context, namespace string, ref autoscaling.CrossVersionObjectReference, sc *autoscaling.Scale) error {
+	target, err := s.Resolve(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.scale.Scales(namespace).Update(ctx, target.GVR.GroupResource(), sc, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("update scale for %s %s/%s: %w", ref.Kind, namespace, ref.Name, err)
+	}
+	return nil
+}
+
+// validateScaleSubresource checks that the given resource exposes a "<resource>/scale" API
+// resource under the given group/version, i.e. that it can actually be targeted by a VPA/HPA.
+func (s *Service) validateScaleSubresource(groupVersion, resource string) error {
+	resourceList, err := s.disc.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		return fmt.Errorf("list server resources for %s: %w", groupVersion, err)
+	}
+
+	scaleResource := resource + "/scale"
+	for _, r := range resourceList.APIResources {
+		if r.Name == scaleResource {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s/%s does not implement the scale subresource", groupVersion, resource)
+}
@@ -0,0 +1,82 @@
+package scheduledscaling
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/mercari/tortoise/api/v1alpha1"
+)
+
+// cronParser accepts the standard five-field Cron expressions (minute hour dom month dow).
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// resolveCronWindow computes the phase of a recurring Cron+Duration schedule at `now`, walking
+// back from `now` to find the most recent recurrence. lastScheduleTime is the recurrence that was
+// last observed active, used to avoid re-triggering the same window after a requeue; it may be zero.
+func resolveCronWindow(schedule v1alpha1.Schedule, lastScheduleTime time.Time, now time.Time) (window Window, firedAt time.Time, err error) {
+	loc := time.UTC
+	if schedule.TimeZone != nil {
+		loc, err = time.LoadLocation(*schedule.TimeZone)
+		if err != nil {
+			return Window{}, time.Time{}, fmt.Errorf("load schedule.timeZone %q: %w", *schedule.TimeZone, err)
+		}
+	}
+	if schedule.Duration == nil {
+		return Window{}, time.Time{}, fmt.Errorf("schedule.duration is required when schedule.cron is set")
+	}
+
+	sched, err := cronParser.Parse(*schedule.Cron)
+	if err != nil {
+		return Window{}, time.Time{}, fmt.Errorf("parse schedule.cron %q: %w", *schedule.Cron, err)
+	}
+
+	nowInLoc := now.In(loc)
+	nextFire := sched.Next(nowInLoc)
+
+	// Walk back far enough to find the most recent fire time at or before now, even if we've never
+	// seen one before (e.g. first reconcile after controller downtime). A fixed 24h isn't enough on
+	// its own: the active window can be longer than a day, and a missed recurrence can still be
+	// within its StartingDeadlineSeconds catch-up window well past 24h ago.
+	lookback := 24 * time.Hour
+	if schedule.Duration.Duration > lookback {
+		lookback = schedule.Duration.Duration
+	}
+	if schedule.StartingDeadlineSeconds != nil {
+		if deadline := time.Duration(*schedule.StartingDeadlineSeconds)*time.Second + schedule.Duration.Duration; deadline > lookback {
+			lookback = deadline
+		}
+	}
+	searchFrom := nowInLoc.Add(-lookback)
+	if !lastScheduleTime.IsZero() && lastScheduleTime.In(loc).After(searchFrom) {
+		// sched.Next is exclusive of its argument, so anchoring exactly on lastScheduleTime would skip
+		// right past it and lose the recurrence that's still active. Back up by a second first -
+		// Cron's finest granularity is a minute, so this still lands on lastScheduleTime itself.
+		searchFrom = lastScheduleTime.In(loc).Add(-time.Second)
+	}
+
+	var lastFire time.Time
+	for next := sched.Next(searchFrom); !next.After(nowInLoc); next = sched.Next(next) {
+		lastFire = next
+	}
+
+	if lastFire.IsZero() {
+		return Window{Phase: v1alpha1.ScheduledScalingPhasePending, NextTransitionAt: nextFire}, time.Time{}, nil
+	}
+
+	if schedule.StartingDeadlineSeconds != nil && !lastFire.Equal(lastScheduleTime) {
+		deadline := time.Duration(*schedule.StartingDeadlineSeconds) * time.Second
+		if nowInLoc.Sub(lastFire) > deadline {
+			// The recurrence fired but wasn't started within its deadline; skip it.
+			return Window{Phase: v1alpha1.ScheduledScalingPhasePending, NextTransitionAt: nextFire}, time.Time{}, nil
+		}
+	}
+
+	activeUntil := lastFire.Add(schedule.Duration.Duration)
+	if nowInLoc.Before(activeUntil) {
+		return Window{Phase: v1alpha1.ScheduledScalingPhaseActive, NextTransitionAt: activeUntil}, lastFire, nil
+	}
+
+	return Window{Phase: v1alpha1.ScheduledScalingPhasePending, NextTransitionAt: nextFire}, time.Time{}, nil
+}
@@ -0,0 +1,338 @@
+package scheduledscaling
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	autoscaling "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mercari/tortoise/api/v1alpha1"
+	autoscalingv1beta3 "github.com/mercari/tortoise/api/v1beta3"
+	"github.com/mercari/tortoise/pkg/event"
+	"github.com/mercari/tortoise/pkg/hpa"
+	"github.com/mercari/tortoise/pkg/scaletarget"
+	"github.com/mercari/tortoise/pkg/vpa"
+)
+
+// Service resolves ScheduledScaling windows and applies/reverts the static overlay
+// onto the Tortoise's effective recommendations.
+type Service struct {
+	c                  client.Client
+	vpaService         *vpa.Service
+	hpaService         *hpa.Service
+	scaleTargetService *scaletarget.Service
+	recorder           record.EventRecorder
+}
+
+func New(c client.Client, vpaService *vpa.Service, hpaService *hpa.Service, scaleTargetService *scaletarget.Service, recorder record.EventRecorder) *Service {
+	return &Service{c: c, vpaService: vpaService, hpaService: hpaService, scaleTargetService: scaleTargetService, recorder: recorder}
+}
+
+// GetScheduledScaling fetches the ScheduledScaling by namespaced name.
+func (s *Service) GetScheduledScaling(ctx context.Context, n types.NamespacedName) (*v1alpha1.ScheduledScaling, error) {
+	ss := &v1alpha1.ScheduledScaling{}
+	if err := s.c.Get(ctx, n, ss); err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
+
+// GetTortoise resolves the Tortoise referenced by TargetRefs.TortoiseName.
+func (s *Service) GetTortoise(ctx context.Context, ss *v1alpha1.ScheduledScaling) (*autoscalingv1beta3.Tortoise, error) {
+	if ss.Spec.TargetRefs.TortoiseName == nil {
+		return nil, fmt.Errorf("targetRefs.tortoiseName is not set on ScheduledScaling %s/%s", ss.Namespace, ss.Name)
+	}
+
+	tortoise := &autoscalingv1beta3.Tortoise{}
+	n := types.NamespacedName{Namespace: ss.Namespace, Name: *ss.Spec.TargetRefs.TortoiseName}
+	if err := s.c.Get(ctx, n, tortoise); err != nil {
+		return nil, fmt.Errorf("get tortoise %s: %w", n.String(), err)
+	}
+	return tortoise, nil
+}
+
+// Window is the resolved state of a Schedule relative to now.
+type Window struct {
+	// Phase is the phase the ScheduledScaling should be in right now.
+	Phase v1alpha1.ScheduledScalingPhase
+	// NextTransitionAt is the next time the phase will change; zero if there's none (e.g. schedule is malformed).
+	NextTransitionAt time.Time
+}
+
+// ResolveWindow computes which phase the ScheduledScaling is in at `now`, and when it will next
+// change, so the caller can requeue precisely at the computed boundary instead of polling. The
+// returned time.Time is the Cron recurrence that's currently active (zero if Schedule.Cron isn't
+// set, or no recurrence is currently active); it becomes Status.LastScheduleTime.
+func (s *Service) ResolveWindow(ss *v1alpha1.ScheduledScaling, now time.Time) (Window, time.Time, error) {
+	schedule := ss.Spec.Schedule
+	if schedule.Cron != nil {
+		var lastScheduleTime time.Time
+		if ss.Status.LastScheduleTime != nil {
+			lastScheduleTime = ss.Status.LastScheduleTime.Time
+		}
+		return resolveCronWindow(schedule, lastScheduleTime, now)
+	}
+
+	if schedule.StartAt == nil || schedule.FinishAt == nil {
+		return Window{}, time.Time{}, fmt.Errorf("schedule.startAt and schedule.finishAt are both required when schedule.cron is not set")
+	}
+
+	startAt, err := time.Parse(time.RFC3339, *schedule.StartAt)
+	if err != nil {
+		return Window{}, time.Time{}, fmt.Errorf("parse schedule.startAt: %w", err)
+	}
+	finishAt, err := time.Parse(time.RFC3339, *schedule.FinishAt)
+	if err != nil {
+		return Window{}, time.Time{}, fmt.Errorf("parse schedule.finishAt: %w", err)
+	}
+	if finishAt.Before(startAt) {
+		return Window{}, time.Time{}, fmt.Errorf("schedule.finishAt (%s) is before schedule.startAt (%s)", finishAt, startAt)
+	}
+
+	switch {
+	case now.Before(startAt):
+		return Window{Phase: v1alpha1.ScheduledScalingPhasePending, NextTransitionAt: startAt}, time.Time{}, nil
+	case now.Before(finishAt):
+		return Window{Phase: v1alpha1.ScheduledScalingPhaseActive, NextTransitionAt: finishAt}, time.Time{}, nil
+	default:
+		return Window{Phase: v1alpha1.ScheduledScalingPhaseCompleted}, time.Time{}, nil
+	}
+}
+
+// ApplyStaticOverlay overlays the Static strategy onto the Tortoise's effective recommendations,
+// bumping MinAllowed on the tortoise-updater VPA while the schedule is active.
+func (s *Service) ApplyStaticOverlay(ctx context.Context, ss *v1alpha1.ScheduledScaling, tortoise *autoscalingv1beta3.Tortoise) error {
+	static := ss.Spec.Strategy.Static
+	if static == nil {
+		return nil
+	}
+
+	updaterVPA, err := s.vpaService.GetTortoiseUpdaterVPA(ctx, tortoise)
+	if err != nil {
+		return fmt.Errorf("get tortoise-updater vpa: %w", err)
+	}
+
+	if err := s.vpaService.ApplyMinimumAllocatedResources(ctx, updaterVPA, static.MinAllocatedResources); err != nil {
+		return fmt.Errorf("apply minimum allocated resources: %w", err)
+	}
+
+	if static.MinimumMinReplicas != nil {
+		tortoiseHPA, err := s.hpaService.GetTortoiseHPA(ctx, tortoise)
+		if err != nil {
+			return fmt.Errorf("get tortoise hpa: %w", err)
+		}
+		if err := s.hpaService.ApplyMinimumMinReplicas(ctx, tortoiseHPA, int32(*static.MinimumMinReplicas)); err != nil {
+			return fmt.Errorf("apply minimum min replicas: %w", err)
+		}
+	}
+
+	if len(ss.Spec.TargetRefs.Followers) > 0 && static.MinimumMinReplicas != nil {
+		if err := s.applyFollowerOverlay(ctx, ss, tortoise, int32(*static.MinimumMinReplicas)); err != nil {
+			return fmt.Errorf("apply follower overlay: %w", err)
+		}
+	}
+
+	s.recorder.Eventf(ss, corev1.EventTypeNormal, event.ScheduledScalingActive, "Applying static overlay to tortoise %s/%s", tortoise.Namespace, tortoise.Name)
+	return nil
+}
+
+// applyFollowerOverlay bumps each follower's replica count to at least its baseline ratio times
+// the primary's MinimumMinReplicas, the ratio being the follower-to-primary replica count observed
+// when the ScheduledScaling first went active. That ratio is
+// captured once into Status.FollowerBaselines and reused on every subsequent activation, so a
+// follower that's since been scaled for unrelated reasons doesn't skew future activations.
+func (s *Service) applyFollowerOverlay(ctx context.Context, ss *v1alpha1.ScheduledScaling, tortoise *autoscalingv1beta3.Tortoise, primaryMinReplicas int32) error {
+	primaryScale, err := s.scaleTargetService.GetScale(ctx, ss.Namespace, tortoise.Spec.TargetRefs.ScaleTargetRef)
+	if err != nil {
+		return fmt.Errorf("get scale of primary target: %w", err)
+	}
+
+	for _, follower := range ss.Spec.TargetRefs.Followers {
+		ref := toCrossVersionObjectReference(follower)
+
+		followerScale, err := s.scaleTargetService.GetScale(ctx, ss.Namespace, ref)
+		if err != nil {
+			return fmt.Errorf("get scale of follower %s: %w", ref.Name, err)
+		}
+
+		ratio, err := s.followerBaselineRatio(ss, follower, primaryScale.Spec.Replicas, followerScale.Spec.Replicas)
+		if err != nil {
+			return err
+		}
+
+		targetReplicas := int32(math.Ceil(ratio * float64(primaryMinReplicas)))
+		if followerScale.Spec.Replicas >= targetReplicas {
+			continue
+		}
+
+		followerScale.Spec.Replicas = targetReplicas
+		if err := s.scaleTargetService.UpdateScale(ctx, ss.Namespace, ref, followerScale); err != nil {
+			return fmt.Errorf("update scale of follower %s: %w", ref.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// followerBaselineRatio returns the cached baseline ratio for the given follower, computing and
+// persisting it into ss.Status.FollowerBaselines if this is the first time it's seen.
+func (s *Service) followerBaselineRatio(ss *v1alpha1.ScheduledScaling, follower v1alpha1.CrossVersionObjectReference, primaryReplicas, followerReplicas int32) (float64, error) {
+	for _, baseline := range ss.Status.FollowerBaselines {
+		if sameFollower(baseline.FollowerRef, follower) {
+			return baseline.Ratio, nil
+		}
+	}
+
+	if primaryReplicas <= 0 {
+		return 0, fmt.Errorf("primary target has no replicas to compute a follower baseline from")
+	}
+
+	ratio := float64(followerReplicas) / float64(primaryReplicas)
+	ss.Status.FollowerBaselines = append(ss.Status.FollowerBaselines, v1alpha1.FollowerBaseline{
+		FollowerRef: follower,
+		Ratio:       ratio,
+	})
+
+	return ratio, nil
+}
+
+func sameFollower(a, b v1alpha1.CrossVersionObjectReference) bool {
+	return ptrStringEqual(a.Kind, b.Kind) && ptrStringEqual(a.Name, b.Name) && ptrStringEqual(a.APIVersion, b.APIVersion)
+}
+
+func ptrStringEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// toCrossVersionObjectReference converts the ScheduledScaling-local CrossVersionObjectReference
+// (which has optional pointer fields) to the k8s-standard type used by the scale target resolver.
+func toCrossVersionObjectReference(ref v1alpha1.CrossVersionObjectReference) autoscaling.CrossVersionObjectReference {
+	out := autoscaling.CrossVersionObjectReference{}
+	if ref.Kind != nil {
+		out.Kind = *ref.Kind
+	}
+	if ref.Name != nil {
+		out.Name = *ref.Name
+	}
+	if ref.APIVersion != nil {
+		out.APIVersion = *ref.APIVersion
+	}
+	return out
+}
+
+// RevertStaticOverlay removes the overlay previously applied by ApplyStaticOverlay, restoring the
+// tortoise-updater VPA to the recommendations computed by Tortoise itself, and scaling followers
+// back down to their baseline ratio of the primary's current replica count.
+func (s *Service) RevertStaticOverlay(ctx context.Context, ss *v1alpha1.ScheduledScaling, tortoise *autoscalingv1beta3.Tortoise) error {
+	updaterVPA, err := s.vpaService.GetTortoiseUpdaterVPA(ctx, tortoise)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("get tortoise-updater vpa: %w", err)
+	}
+
+	if _, err := s.vpaService.UpdateVPAContainerResourcePolicy(ctx, tortoise, updaterVPA); err != nil {
+		return fmt.Errorf("revert minimum allocated resources: %w", err)
+	}
+
+	if ss.Spec.Strategy.Static != nil && ss.Spec.Strategy.Static.MinimumMinReplicas != nil {
+		tortoiseHPA, err := s.hpaService.GetTortoiseHPA(ctx, tortoise)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("get tortoise hpa: %w", err)
+		}
+		if err := s.hpaService.RevertMinimumMinReplicas(ctx, tortoiseHPA); err != nil {
+			return fmt.Errorf("revert minimum min replicas: %w", err)
+		}
+	}
+
+	if err := s.revertFollowerOverlay(ctx, ss, tortoise); err != nil {
+		return fmt.Errorf("revert follower overlay: %w", err)
+	}
+
+	s.recorder.Eventf(ss, corev1.EventTypeNormal, event.ScheduledScalingCompleted, "Reverted static overlay on tortoise %s/%s", tortoise.Namespace, tortoise.Name)
+	return nil
+}
+
+// revertFollowerOverlay scales each follower recorded in ss.Status.FollowerBaselines back down to
+// its baseline ratio of the primary's current replica count, undoing the boost applyFollowerOverlay
+// applied while the schedule was active. The baselines themselves are left in place so the next
+// activation reuses the same ratio instead of recomputing it from whatever the followers happen to
+// be at by then.
+func (s *Service) revertFollowerOverlay(ctx context.Context, ss *v1alpha1.ScheduledScaling, tortoise *autoscalingv1beta3.Tortoise) error {
+	if len(ss.Status.FollowerBaselines) == 0 {
+		return nil
+	}
+
+	primaryScale, err := s.scaleTargetService.GetScale(ctx, ss.Namespace, tortoise.Spec.TargetRefs.ScaleTargetRef)
+	if err != nil {
+		return fmt.Errorf("get scale of primary target: %w", err)
+	}
+
+	for _, baseline := range ss.Status.FollowerBaselines {
+		ref := toCrossVersionObjectReference(baseline.FollowerRef)
+
+		followerScale, err := s.scaleTargetService.GetScale(ctx, ss.Namespace, ref)
+		if err != nil {
+			return fmt.Errorf("get scale of follower %s: %w", ref.Name, err)
+		}
+
+		targetReplicas := int32(math.Ceil(baseline.Ratio * float64(primaryScale.Spec.Replicas)))
+		if followerScale.Spec.Replicas <= targetReplicas {
+			continue
+		}
+
+		followerScale.Spec.Replicas = targetReplicas
+		if err := s.scaleTargetService.UpdateScale(ctx, ss.Namespace, ref, followerScale); err != nil {
+			return fmt.Errorf("update scale of follower %s: %w", ref.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateStatus sets the ScheduledScalingPhase, LastScheduleTime and NextScheduleTime, recording
+// LastTransitionTime and emitting an event whenever the phase changes.
+func (s *Service) UpdateStatus(ctx context.Context, ss *v1alpha1.ScheduledScaling, window Window, lastScheduleTime, now time.Time) error {
+	changed := ss.Status.ScheduledScalingPhase != window.Phase
+	old := ss.Status.ScheduledScalingPhase
+
+	ss.Status.ScheduledScalingPhase = window.Phase
+	if !lastScheduleTime.IsZero() {
+		t := metav1.NewTime(lastScheduleTime)
+		ss.Status.LastScheduleTime = &t
+	}
+	if !window.NextTransitionAt.IsZero() {
+		t := metav1.NewTime(window.NextTransitionAt)
+		ss.Status.NextScheduleTime = &t
+	} else {
+		ss.Status.NextScheduleTime = nil
+	}
+	if changed {
+		t := metav1.NewTime(now)
+		ss.Status.LastTransitionTime = &t
+	}
+
+	if err := s.c.Status().Update(ctx, ss); err != nil {
+		return fmt.Errorf("update scheduledscaling status: %w", err)
+	}
+
+	if changed {
+		s.recorder.Eventf(ss, corev1.EventTypeNormal, event.ScheduledScalingPhaseChanged, "Phase transitioned from %s to %s", old, window.Phase)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package health
+
+import "time"
+
+// BackoffRequeueAfter computes an exponential backoff requeue interval for a monitor VPA that
+// hasn't yet reached StatusHealthy, so that a persistently unhealthy VPA doesn't get requeued at a
+// fixed interval forever. consecutiveUnhealthy is how many reconciles in a row the VPA has been
+// non-healthy; it resets to 0 as soon as the VPA becomes healthy again.
+func BackoffRequeueAfter(consecutiveUnhealthy int, base, maxBackoff time.Duration) time.Duration {
+	if consecutiveUnhealthy <= 0 {
+		return base
+	}
+
+	backoff := base
+	for i := 0; i < consecutiveUnhealthy; i++ {
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,168 @@
+// Package health turns a VPA's raw Status.Conditions and per-container recommendation coverage
+// into a structured verdict the rest of Tortoise can act on, instead of the single
+// all-or-nothing boolean the monitor VPA readiness check used to produce.
+package health
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	v1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+
+	autoscalingv1beta3 "github.com/mercari/tortoise/api/v1beta3"
+)
+
+// ConditionType is the Tortoise.Status.Conditions type this package reports under.
+const ConditionType = "VPAHealthy"
+
+// Status is the overall verdict for a monitor VPA.
+type Status string
+
+const (
+	// StatusHealthy means the VPA is supplying a full, fresh recommendation for every container
+	// the Tortoise expects one for.
+	StatusHealthy Status = "Healthy"
+	// StatusDegraded means the VPA is working but something about its recommendation shouldn't be
+	// fully trusted yet (e.g. still fetching history, or a container's recommendation is stale).
+	StatusDegraded Status = "Degraded"
+	// StatusUnhealthy means the VPA isn't supplying a usable recommendation at all.
+	StatusUnhealthy Status = "Unhealthy"
+)
+
+// Reason is a specific contributor to a non-healthy Status.
+type Reason string
+
+const (
+	ReasonRecommendationNotProvided Reason = "RecommendationNotProvided"
+	ReasonMissingContainerCoverage  Reason = "MissingContainerCoverage"
+	ReasonLowConfidence             Reason = "LowConfidence"
+	ReasonFetchingHistory           Reason = "FetchingHistory"
+	ReasonConfigDeprecated          Reason = "ConfigDeprecated"
+	ReasonConfigUnsupported         Reason = "ConfigUnsupported"
+	ReasonStaleRecommendation       Reason = "StaleRecommendation"
+)
+
+// VPAHealth is the structured health of a monitor VPA.
+type VPAHealth struct {
+	Status  Status
+	Reasons []Reason
+}
+
+// Ready reports whether the VPA's recommendation is healthy enough to be consumed as-is. This
+// replaces the old isMonitorVPAReady boolean predicate.
+func (h VPAHealth) Ready() bool {
+	return h.Status == StatusHealthy
+}
+
+// Message renders the reasons as a single human-readable string, suitable for an event or a
+// condition's Message field.
+func (h VPAHealth) Message() string {
+	if len(h.Reasons) == 0 {
+		return "VPA recommendation is healthy"
+	}
+	reasons := make([]string, 0, len(h.Reasons))
+	for _, r := range h.Reasons {
+		reasons = append(reasons, string(r))
+	}
+	return fmt.Sprintf("VPA is %s: %s", strings.ToLower(string(h.Status)), strings.Join(reasons, ", "))
+}
+
+// Condition renders h as a metav1.Condition under ConditionType, suitable for upserting into
+// Tortoise.Status.Conditions. The caller is responsible for preserving LastTransitionTime from the
+// previous condition of the same Type when the Status hasn't changed.
+func Condition(h VPAHealth, observedGeneration int64) metav1.Condition {
+	status := metav1.ConditionFalse
+	if h.Ready() {
+		status = metav1.ConditionTrue
+	}
+	return metav1.Condition{
+		Type:               ConditionType,
+		Status:             status,
+		ObservedGeneration: observedGeneration,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             conditionReason(h),
+		Message:            h.Message(),
+	}
+}
+
+func conditionReason(h VPAHealth) string {
+	if len(h.Reasons) == 0 {
+		return "Healthy"
+	}
+	return string(h.Reasons[0])
+}
+
+// Evaluate inspects the VPA's Status.Conditions and its per-container recommendation coverage
+// against the containers the Tortoise expects recommendations for. staleContainers is the set of
+// container names whose Target recommendation hasn't changed for at least the caller's configured
+// number of reconciles, as tracked by Service. Status.Recommendation is nil until the VPA
+// recommender has run at least once, which Evaluate treats the same as RecommendationProvided being
+// false rather than dereferencing it.
+func Evaluate(vpa *v1.VerticalPodAutoscaler, tortoise *autoscalingv1beta3.Tortoise, staleContainers sets.Set[string]) VPAHealth {
+	var reasons []Reason
+
+	conditions := map[v1.VerticalPodAutoscalerConditionType]corev1.ConditionStatus{}
+	for _, c := range vpa.Status.Conditions {
+		conditions[c.Type] = c.Status
+	}
+
+	recommendationProvided := conditions[v1.RecommendationProvided] == corev1.ConditionTrue && vpa.Status.Recommendation != nil
+	if !recommendationProvided {
+		reasons = append(reasons, ReasonRecommendationNotProvided)
+	}
+	if conditions[v1.LowConfidence] == corev1.ConditionTrue {
+		reasons = append(reasons, ReasonLowConfidence)
+	}
+	if conditions[v1.FetchingHistory] == corev1.ConditionTrue {
+		reasons = append(reasons, ReasonFetchingHistory)
+	}
+	if conditions[v1.ConfigDeprecated] == corev1.ConditionTrue {
+		reasons = append(reasons, ReasonConfigDeprecated)
+	}
+	if conditions[v1.ConfigUnsupported] == corev1.ConditionTrue {
+		reasons = append(reasons, ReasonConfigUnsupported)
+	}
+
+	containerInTortoise := sets.New[string]()
+	for _, p := range tortoise.Status.AutoscalingPolicy {
+		containerInTortoise.Insert(p.ContainerName)
+	}
+
+	containerInVPA := sets.New[string]()
+	missingCoverage := false
+	if vpa.Status.Recommendation != nil {
+		for _, c := range vpa.Status.Recommendation.ContainerRecommendations {
+			containerInVPA.Insert(c.ContainerName)
+			if c.Target.Cpu().IsZero() || c.Target.Memory().IsZero() {
+				missingCoverage = true
+			}
+		}
+	}
+	if !containerInTortoise.Equal(containerInVPA) {
+		missingCoverage = true
+	}
+	if missingCoverage {
+		reasons = append(reasons, ReasonMissingContainerCoverage)
+	}
+
+	if staleContainers.Len() > 0 {
+		reasons = append(reasons, ReasonStaleRecommendation)
+	}
+
+	status := StatusHealthy
+	switch {
+	case !recommendationProvided || missingCoverage:
+		status = StatusUnhealthy
+	case len(reasons) > 0:
+		status = StatusDegraded
+	}
+
+	sort.Slice(reasons, func(i, j int) bool { return reasons[i] < reasons[j] })
+
+	return VPAHealth{Status: status, Reasons: reasons}
+}
@@ -3,12 +3,15 @@ package vpa
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	autoscaling "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	v1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/client/clientset/versioned"
@@ -16,22 +19,41 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 
+	"github.com/mercari/tortoise/api/v1alpha1"
 	autoscalingv1beta3 "github.com/mercari/tortoise/api/v1beta3"
 	"github.com/mercari/tortoise/pkg/annotation"
 	"github.com/mercari/tortoise/pkg/event"
+	"github.com/mercari/tortoise/pkg/scaletarget"
+	"github.com/mercari/tortoise/pkg/vpa/health"
 )
 
 type Service struct {
-	c        versioned.Interface
-	recorder record.EventRecorder
+	c                  versioned.Interface
+	scaleTargetService *scaletarget.Service
+	recorder           record.EventRecorder
+
+	// staleTrackingMu guards staleTracking, lastHealthStatus and consecutiveUnhealthy, which are
+	// populated from GetTortoiseMonitorVPA on every reconcile to detect stuck recommendations and
+	// health transitions across calls.
+	staleTrackingMu      sync.Mutex
+	staleTracking        map[types.NamespacedName]map[string]containerTarget
+	lastHealthStatus     map[types.NamespacedName]health.Status
+	consecutiveUnhealthy map[types.NamespacedName]int
 }
 
-func New(c *rest.Config, recorder record.EventRecorder) (*Service, error) {
+// monitorVPABackoffBase and monitorVPABackoffMax bound the exponential requeue delay
+// GetTortoiseMonitorVPA returns while a monitor VPA's health stays short of health.StatusHealthy.
+const (
+	monitorVPABackoffBase = 30 * time.Second
+	monitorVPABackoffMax  = 10 * time.Minute
+)
+
+func New(c *rest.Config, scaleTargetService *scaletarget.Service, recorder record.EventRecorder) (*Service, error) {
 	cli, err := versioned.NewForConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	return &Service{c: cli, recorder: recorder}, nil
+	return &Service{c: cli, scaleTargetService: scaleTargetService, recorder: recorder}, nil
 }
 
 const tortoiseMonitorVPANamePrefix = "tortoise-monitor-"
@@ -152,14 +174,7 @@ func (c *Service) UpdateVPAContainerResourcePolicy(ctx context.Context, tortoise
 	var err error
 
 	updateFn := func() error {
-		crp := make([]v1.ContainerResourcePolicy, 0, len(tortoise.Spec.ResourcePolicy))
-		for _, c := range tortoise.Spec.ResourcePolicy {
-			crp = append(crp, v1.ContainerResourcePolicy{
-				ContainerName: c.ContainerName,
-				MinAllowed:    c.MinAllocatedResources,
-			})
-		}
-		vpa.Spec.ResourcePolicy = &v1.PodResourcePolicy{ContainerPolicies: crp}
+		vpa.Spec.ResourcePolicy = &v1.PodResourcePolicy{ContainerPolicies: containerResourcePolicies(tortoise, false)}
 		retVPA, err = c.c.AutoscalingV1().VerticalPodAutoscalers(vpa.Namespace).Update(ctx, vpa, metav1.UpdateOptions{})
 		return err
 	}
@@ -171,7 +186,86 @@ func (c *Service) UpdateVPAContainerResourcePolicy(ctx context.Context, tortoise
 	return retVPA, nil
 }
 
+// ApplyMinimumAllocatedResources overlays the given per-container minimum resources onto the
+// updater VPA's ContainerResourcePolicy MinAllowed, on top of whatever the Tortoise resource
+// policy already requires. It only ever raises MinAllowed: a nil/empty minAllocatedResources
+// leaves it as it is. UpdateVPAContainerResourcePolicy resets it to just the Tortoise-driven MinAllowed.
+func (c *Service) ApplyMinimumAllocatedResources(ctx context.Context, vpa *v1.VerticalPodAutoscaler, minAllocatedResources []v1alpha1.ContainerResourceRequests) error {
+	overlay := make(map[string]corev1.ResourceList, len(minAllocatedResources))
+	for _, r := range minAllocatedResources {
+		overlay[r.ContainerName] = r.Resource
+	}
+
+	updateFn := func() error {
+		if vpa.Spec.ResourcePolicy == nil {
+			vpa.Spec.ResourcePolicy = &v1.PodResourcePolicy{}
+		}
+		for i, cp := range vpa.Spec.ResourcePolicy.ContainerPolicies {
+			min, ok := overlay[cp.ContainerName]
+			if !ok {
+				continue
+			}
+			vpa.Spec.ResourcePolicy.ContainerPolicies[i].MinAllowed = mergeResourceList(cp.MinAllowed, min)
+		}
+
+		newVPA, err := c.c.AutoscalingV1().VerticalPodAutoscalers(vpa.Namespace).Update(ctx, vpa, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		*vpa = *newVPA
+		return nil
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, updateFn); err != nil {
+		return fmt.Errorf("update VPA (%s/%s) ContainerResourcePolicy: %w", vpa.Namespace, vpa.Name, err)
+	}
+
+	return nil
+}
+
+// containerResourcePolicies builds the VPA ContainerResourcePolicy list from the Tortoise's resource
+// policy, threading through per-container ControlledResources (e.g. CPU-only or memory-only) so the
+// VPA only ever recommends/applies the resources the Tortoise is configured to manage vertically.
+func containerResourcePolicies(tortoise *autoscalingv1beta3.Tortoise, onlyWithMinAllowed bool) []v1.ContainerResourcePolicy {
+	crp := make([]v1.ContainerResourcePolicy, 0, len(tortoise.Spec.ResourcePolicy))
+	for _, c := range tortoise.Spec.ResourcePolicy {
+		if onlyWithMinAllowed && c.MinAllocatedResources == nil {
+			continue
+		}
+		cp := v1.ContainerResourcePolicy{
+			ContainerName: c.ContainerName,
+			MinAllowed:    c.MinAllocatedResources,
+		}
+		if len(c.ContainerControlledResources) > 0 {
+			controlled := make([]corev1.ResourceName, len(c.ContainerControlledResources))
+			copy(controlled, c.ContainerControlledResources)
+			cp.ControlledResources = &controlled
+		}
+		crp = append(crp, cp)
+	}
+	return crp
+}
+
+// mergeResourceList returns a copy of base with every quantity in overlay that's larger applied on top.
+func mergeResourceList(base, overlay corev1.ResourceList) corev1.ResourceList {
+	merged := base.DeepCopy()
+	if merged == nil {
+		merged = corev1.ResourceList{}
+	}
+	for name, q := range overlay {
+		if existing, ok := merged[name]; !ok || q.Cmp(existing) > 0 {
+			merged[name] = q
+		}
+	}
+	return merged
+}
+
 func (c *Service) CreateTortoiseMonitorVPA(ctx context.Context, tortoise *autoscalingv1beta3.Tortoise) (*v1.VerticalPodAutoscaler, *autoscalingv1beta3.Tortoise, error) {
+	target, err := c.scaleTargetService.Resolve(ctx, tortoise.Spec.TargetRefs.ScaleTargetRef)
+	if err != nil {
+		return nil, tortoise, fmt.Errorf("resolve scale target: %w", err)
+	}
+
 	off := v1.UpdateModeOff
 	vpa := &v1.VerticalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
@@ -184,9 +278,9 @@ func (c *Service) CreateTortoiseMonitorVPA(ctx context.Context, tortoise *autosc
 		},
 		Spec: v1.VerticalPodAutoscalerSpec{
 			TargetRef: &autoscaling.CrossVersionObjectReference{
-				Kind:       "Deployment",
+				Kind:       target.Kind,
 				Name:       tortoise.Spec.TargetRefs.ScaleTargetRef.Name,
-				APIVersion: "apps/v1",
+				APIVersion: target.APIVersion,
 			},
 			UpdatePolicy: &v1.PodUpdatePolicy{
 				UpdateMode: &off,
@@ -194,24 +288,14 @@ func (c *Service) CreateTortoiseMonitorVPA(ctx context.Context, tortoise *autosc
 			ResourcePolicy: &v1.PodResourcePolicy{},
 		},
 	}
-	crp := make([]v1.ContainerResourcePolicy, 0, len(tortoise.Spec.ResourcePolicy))
-	for _, c := range tortoise.Spec.ResourcePolicy {
-		if c.MinAllocatedResources == nil {
-			continue
-		}
-		crp = append(crp, v1.ContainerResourcePolicy{
-			ContainerName: c.ContainerName,
-			MinAllowed:    c.MinAllocatedResources,
-		})
-	}
-	vpa.Spec.ResourcePolicy.ContainerPolicies = crp
+	vpa.Spec.ResourcePolicy.ContainerPolicies = containerResourcePolicies(tortoise, true)
 
 	tortoise.Status.Targets.VerticalPodAutoscalers = append(tortoise.Status.Targets.VerticalPodAutoscalers, autoscalingv1beta3.TargetStatusVerticalPodAutoscaler{
 		Name: vpa.Name,
 		Role: autoscalingv1beta3.VerticalPodAutoscalerRoleMonitor,
 	})
 
-	vpa, err := c.c.AutoscalingV1().VerticalPodAutoscalers(vpa.Namespace).Create(ctx, vpa, metav1.CreateOptions{})
+	vpa, err = c.c.AutoscalingV1().VerticalPodAutoscalers(vpa.Namespace).Create(ctx, vpa, metav1.CreateOptions{})
 	if err != nil {
 		return nil, tortoise, err
 	}
@@ -221,42 +305,215 @@ func (c *Service) CreateTortoiseMonitorVPA(ctx context.Context, tortoise *autosc
 	return vpa, tortoise, nil
 }
 
-func (c *Service) GetTortoiseMonitorVPA(ctx context.Context, tortoise *autoscalingv1beta3.Tortoise) (*v1.VerticalPodAutoscaler, bool, error) {
-	vpa, err := c.c.AutoscalingV1().VerticalPodAutoscalers(tortoise.Namespace).Get(ctx, TortoiseMonitorVPAName(tortoise.Name), metav1.GetOptions{})
+// updateModeForTortoise resolves the UpdateMode the tortoise-updater VPA should run with, based on
+// Spec.VPAConfig.UpdateMode. Recommend publishes recommendations without letting the VPA evict
+// pods (UpdateModeOff); Auto lets the upstream VPA admission controller perform eviction directly.
+// Disabled is handled by the caller (ReconcileTortoiseUpdaterVPA), which deletes the updater VPA
+// instead of creating one.
+func updateModeForTortoise(tortoise *autoscalingv1beta3.Tortoise) *v1.UpdateMode {
+	mode := v1.UpdateModeOff
+	if tortoise.Spec.VPAConfig.UpdateMode == autoscalingv1beta3.VPAUpdateModeAuto {
+		mode = v1.UpdateModeAuto
+	}
+	return &mode
+}
+
+// ReconcileTortoiseUpdaterVPA ensures the tortoise-updater VPA matches Spec.VPAConfig.UpdateMode:
+// VPAUpdateModeDisabled deletes it (or leaves it deleted), so Tortoise stops vertically scaling the
+// workload altogether, while Recommend/Auto create it if missing and otherwise keep its UpdateMode
+// in sync with the Tortoise on every reconcile.
+func (c *Service) ReconcileTortoiseUpdaterVPA(ctx context.Context, tortoise *autoscalingv1beta3.Tortoise) (*v1.VerticalPodAutoscaler, error) {
+	if tortoise.Spec.VPAConfig.UpdateMode == autoscalingv1beta3.VPAUpdateModeDisabled {
+		return nil, c.DeleteTortoiseUpdaterVPA(ctx, tortoise)
+	}
+
+	vpa, err := c.GetTortoiseUpdaterVPA(ctx, tortoise)
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to get updater vpa on tortoise: %w", err)
+		if apierrors.IsNotFound(err) {
+			return c.CreateTortoiseUpdaterVPA(ctx, tortoise)
+		}
+		return nil, fmt.Errorf("get tortoise-updater vpa: %w", err)
 	}
 
-	return vpa, isMonitorVPAReady(vpa, tortoise), nil
-}
+	mode := updateModeForTortoise(tortoise)
+	if vpa.Spec.UpdatePolicy != nil && vpa.Spec.UpdatePolicy.UpdateMode != nil && *vpa.Spec.UpdatePolicy.UpdateMode == *mode {
+		return vpa, nil
+	}
 
-func isMonitorVPAReady(vpa *v1.VerticalPodAutoscaler, tortoise *autoscalingv1beta3.Tortoise) bool {
-	provided := false
-	for _, c := range vpa.Status.Conditions {
-		if c.Type == v1.RecommendationProvided && c.Status == corev1.ConditionTrue {
-			provided = true
+	updateFn := func() error {
+		if vpa.Spec.UpdatePolicy == nil {
+			vpa.Spec.UpdatePolicy = &v1.PodUpdatePolicy{}
 		}
+		vpa.Spec.UpdatePolicy.UpdateMode = mode
+
+		newVPA, err := c.c.AutoscalingV1().VerticalPodAutoscalers(vpa.Namespace).Update(ctx, vpa, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		*vpa = *newVPA
+		return nil
 	}
-	if !provided {
-		return false
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, updateFn); err != nil {
+		return nil, fmt.Errorf("update VPA (%s/%s) UpdateMode: %w", vpa.Namespace, vpa.Name, err)
 	}
 
-	// Check if VPA has the recommendation for all the containers registered in the tortoise.
-	containerInTortoise := sets.New[string]()
-	for _, p := range tortoise.Status.AutoscalingPolicy {
-		containerInTortoise.Insert(p.ContainerName)
+	return vpa, nil
+}
+
+// CreateTortoiseUpdaterVPA creates the tortoise-updater VPA with the UpdateMode resolved from
+// Spec.VPAConfig.UpdateMode. Callers are expected to have already checked that UpdateMode isn't
+// VPAUpdateModeDisabled, in which case DeleteTortoiseUpdaterVPA should be used instead; most callers
+// should go through ReconcileTortoiseUpdaterVPA, which handles that dispatch.
+func (c *Service) CreateTortoiseUpdaterVPA(ctx context.Context, tortoise *autoscalingv1beta3.Tortoise) (*v1.VerticalPodAutoscaler, error) {
+	target, err := c.scaleTargetService.Resolve(ctx, tortoise.Spec.TargetRefs.ScaleTargetRef)
+	if err != nil {
+		return nil, fmt.Errorf("resolve scale target: %w", err)
+	}
+
+	vpa := &v1.VerticalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tortoise.Namespace,
+			Name:      TortoiseUpdaterVPAName(tortoise.Name),
+			Annotations: map[string]string{
+				annotation.ManagedByTortoiseAnnotation: "true",
+				annotation.TortoiseNameAnnotation:      tortoise.Name,
+			},
+		},
+		Spec: v1.VerticalPodAutoscalerSpec{
+			TargetRef: &autoscaling.CrossVersionObjectReference{
+				Kind:       target.Kind,
+				Name:       tortoise.Spec.TargetRefs.ScaleTargetRef.Name,
+				APIVersion: target.APIVersion,
+			},
+			UpdatePolicy: &v1.PodUpdatePolicy{
+				UpdateMode: updateModeForTortoise(tortoise),
+			},
+			ResourcePolicy: &v1.PodResourcePolicy{
+				ContainerPolicies: containerResourcePolicies(tortoise, false),
+			},
+		},
+	}
+
+	vpa, err = c.c.AutoscalingV1().VerticalPodAutoscalers(vpa.Namespace).Create(ctx, vpa, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	c.recorder.Event(tortoise, corev1.EventTypeNormal, event.VPACreated, fmt.Sprintf("Initialized an updater VPA %s/%s", vpa.Namespace, vpa.Name))
+
+	return vpa, nil
+}
+
+// GetTortoiseMonitorVPA fetches the monitor VPA and evaluates its health. The returned duration is
+// how long the caller should wait before its next reconcile: it backs off exponentially, via
+// health.BackoffRequeueAfter, for as long as the VPA stays short of health.StatusHealthy, so a
+// persistently unhealthy VPA (e.g. one stuck ConfigUnsupported) doesn't get requeued at a fixed
+// interval forever.
+func (c *Service) GetTortoiseMonitorVPA(ctx context.Context, tortoise *autoscalingv1beta3.Tortoise) (*v1.VerticalPodAutoscaler, health.VPAHealth, time.Duration, error) {
+	vpa, err := c.c.AutoscalingV1().VerticalPodAutoscalers(tortoise.Namespace).Get(ctx, TortoiseMonitorVPAName(tortoise.Name), metav1.GetOptions{})
+	if err != nil {
+		return nil, health.VPAHealth{}, 0, fmt.Errorf("failed to get updater vpa on tortoise: %w", err)
+	}
+
+	n := types.NamespacedName{Namespace: tortoise.Namespace, Name: tortoise.Name}
+	stale := c.trackRecommendationStaleness(n, vpa)
+	h := health.Evaluate(vpa, tortoise, stale)
+	c.recordHealthTransition(tortoise, h)
+	consecutiveUnhealthy := c.trackConsecutiveUnhealthy(n, h)
+
+	return vpa, h, health.BackoffRequeueAfter(consecutiveUnhealthy, monitorVPABackoffBase, monitorVPABackoffMax), nil
+}
+
+// trackConsecutiveUnhealthy counts how many calls in a row have observed h.Status short of
+// health.StatusHealthy for the given Tortoise, resetting to 0 as soon as it's healthy again.
+func (c *Service) trackConsecutiveUnhealthy(tortoise types.NamespacedName, h health.VPAHealth) int {
+	c.staleTrackingMu.Lock()
+	defer c.staleTrackingMu.Unlock()
+
+	if c.consecutiveUnhealthy == nil {
+		c.consecutiveUnhealthy = map[types.NamespacedName]int{}
 	}
 
-	containerInVPA := sets.New[string]()
-	for _, c := range vpa.Status.Recommendation.ContainerRecommendations {
-		containerInVPA.Insert(c.ContainerName)
-		if c.Target.Cpu().IsZero() || c.Target.Memory().IsZero() {
-			// something wrong with the recommendation.
-			return false
+	if h.Status == health.StatusHealthy {
+		delete(c.consecutiveUnhealthy, tortoise)
+		return 0
+	}
+
+	c.consecutiveUnhealthy[tortoise]++
+	return c.consecutiveUnhealthy[tortoise]
+}
+
+// staleAfterReconciles is how many consecutive reconciles a container's Target recommendation can
+// stay unchanged before it's considered stale, analogous to a metric not moving.
+const staleAfterReconciles = 5
+
+type containerTarget struct {
+	cpu, memory string // resource.Quantity.String(), compared for equality across reconciles
+	reconciles  int
+}
+
+// trackRecommendationStaleness compares the current VPA recommendation Target against what was
+// observed on the previous call for the same Tortoise, per container, and returns the set of
+// containers whose Target hasn't changed for at least staleAfterReconciles calls in a row.
+func (c *Service) trackRecommendationStaleness(tortoise types.NamespacedName, vpa *v1.VerticalPodAutoscaler) sets.Set[string] {
+	c.staleTrackingMu.Lock()
+	defer c.staleTrackingMu.Unlock()
+
+	if c.staleTracking == nil {
+		c.staleTracking = map[types.NamespacedName]map[string]containerTarget{}
+	}
+	previous := c.staleTracking[tortoise]
+	stale := sets.New[string]()
+
+	var current map[string]containerTarget
+	if vpa.Status.Recommendation != nil {
+		current = make(map[string]containerTarget, len(vpa.Status.Recommendation.ContainerRecommendations))
+		for _, rec := range vpa.Status.Recommendation.ContainerRecommendations {
+			t := containerTarget{cpu: rec.Target.Cpu().String(), memory: rec.Target.Memory().String()}
+			if prev, ok := previous[rec.ContainerName]; ok && prev.cpu == t.cpu && prev.memory == t.memory {
+				t.reconciles = prev.reconciles + 1
+			} else {
+				t.reconciles = 1
+			}
+			if t.reconciles >= staleAfterReconciles {
+				stale.Insert(rec.ContainerName)
+			}
+			current[rec.ContainerName] = t
 		}
 	}
 
-	return containerInTortoise.Equal(containerInVPA)
+	c.staleTracking[tortoise] = current
+	return stale
+}
+
+// recordHealthTransition sets the VPAHealthy condition on tortoise.Status.Conditions (the caller
+// is still responsible for persisting the status update) and emits a VPAUnhealthy/VPADegraded
+// event the first time the VPA's health moves away from health.StatusHealthy, so reconciles that
+// keep observing the same bad health don't spam events every loop.
+func (c *Service) recordHealthTransition(tortoise *autoscalingv1beta3.Tortoise, h health.VPAHealth) {
+	n := types.NamespacedName{Namespace: tortoise.Namespace, Name: tortoise.Name}
+
+	c.staleTrackingMu.Lock()
+	last, seen := c.lastHealthStatus[n]
+	if c.lastHealthStatus == nil {
+		c.lastHealthStatus = map[types.NamespacedName]health.Status{}
+	}
+	c.lastHealthStatus[n] = h.Status
+	c.staleTrackingMu.Unlock()
+
+	meta.SetStatusCondition(&tortoise.Status.Conditions, health.Condition(h, tortoise.Generation))
+
+	if seen && last == h.Status {
+		return
+	}
+
+	switch h.Status {
+	case health.StatusUnhealthy:
+		c.recorder.Event(tortoise, corev1.EventTypeWarning, event.VPAUnhealthy, h.Message())
+	case health.StatusDegraded:
+		c.recorder.Event(tortoise, corev1.EventTypeWarning, event.VPADegraded, h.Message())
+	}
 }
 
 func SetAllVerticalContainerResourcePhaseWorking(tortoise *autoscalingv1beta3.Tortoise, now time.Time) *autoscalingv1beta3.Tortoise {